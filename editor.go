@@ -0,0 +1,390 @@
+package xui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tomyl/gocui"
+)
+
+// A History lets a LineEditor traverse previously entered lines with the
+// arrow keys, and records newly accepted lines.
+type History interface {
+	Add(string)
+	Prev() (string, bool)
+	Next() (string, bool)
+	Reset()
+}
+
+// A Completer proposes completions for the text up to cursor in line.
+// replaceFrom is the byte offset in line where the chosen completion should
+// be spliced in.
+type Completer func(line string, cursor int) (completions []string, replaceFrom int)
+
+// A LineEditor is a gocui.Editor implementing common Emacs-style readline
+// motions: Ctrl-A/E (start/end), Ctrl-W (delete previous word), Alt-B/F
+// (word back/forward), Ctrl-U/K (kill to start/end), Ctrl-Y (yank),
+// Ctrl-R (incremental reverse history search), history traversal with
+// arrow-up/down and Tab completion.
+type LineEditor struct {
+	offset   int
+	callback func(bool, string)
+
+	history   History
+	completer Completer
+	onChange  func(string)
+
+	kill string
+
+	searching    bool
+	searchQuery  string
+	searchPrefix string
+	searchSaved  string
+	searchOrig   string
+
+	completions   []string
+	completionIdx int
+	completionAt  int
+}
+
+// NewLineEditor builds a LineEditor. offset is the number of leading bytes
+// in the view's buffer (typically a prompt prefix) that are not part of the
+// editable content.
+func NewLineEditor(g *gocui.Gui, offset int, callback func(bool, string)) *LineEditor {
+	return &LineEditor{offset: offset, callback: callback}
+}
+
+// SetHistory installs a History used for arrow-up/down traversal.
+func (e *LineEditor) SetHistory(h History) {
+	e.history = h
+}
+
+// SetCompleter installs a Completer used for Tab completion.
+func (e *LineEditor) SetCompleter(c Completer) {
+	e.completer = c
+}
+
+// SetOnChange installs a hook called with the current content after every
+// consumed key, including cursor motions that leave the content unchanged
+// (but not on the final Enter/Esc, which go through the callback passed to
+// NewLineEditor instead).
+func (e *LineEditor) SetOnChange(f func(string)) {
+	e.onChange = f
+}
+
+// PromptEditor builds a gocui.Editor letting user enter a line of text. It
+// returns a LineEditor with no History or Completer set, so arrow-up/down,
+// Ctrl-R and Tab do nothing; the other motions and kill/yank keys work.
+// Callers that want history or completion should build a LineEditor
+// directly and call SetHistory/SetCompleter.
+func PromptEditor(g *gocui.Gui, offset int, callback func(bool, string)) gocui.Editor {
+	return NewLineEditor(g, offset, callback)
+}
+
+// Edit implements gocui.Editor.
+func (e *LineEditor) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) bool {
+	if e.searching {
+		return e.editSearch(v, key, ch, mod)
+	}
+
+	line, cx := e.lineAndCursor(v)
+	cancel := false
+	done := false
+	consumed := true
+
+	switch {
+	case ch != 0 && mod == gocui.ModAlt:
+		switch ch {
+		case 'b':
+			v.SetCursor(e.wordBack(line, cx), 0)
+		case 'f':
+			v.SetCursor(e.wordForward(line, cx), 0)
+		default:
+			consumed = false
+		}
+	case ch != 0 && mod == 0:
+		e.resetCompletion()
+		v.EditWrite(ch)
+	case key == gocui.KeySpace:
+		e.resetCompletion()
+		v.EditWrite(' ')
+	case key == gocui.KeyBackspace || key == gocui.KeyBackspace2:
+		if cx > e.offset {
+			v.EditDelete(true)
+		} else {
+			cancel = true
+		}
+	case key == gocui.KeyDelete:
+		v.EditDelete(false)
+	case key == gocui.KeyCtrlA:
+		v.SetCursor(e.offset, 0)
+	case key == gocui.KeyCtrlE:
+		v.SetCursor(len(line), 0)
+	case key == gocui.KeyCtrlW:
+		from := e.wordBack(line, cx)
+		e.kill = line[from:cx]
+		e.deleteRange(v, from, cx)
+	case key == gocui.KeyCtrlU:
+		from := e.offset
+		e.kill = line[from:cx]
+		e.deleteRange(v, from, cx)
+	case key == gocui.KeyCtrlK:
+		e.kill = line[cx:]
+		e.deleteRange(v, cx, len(line))
+	case key == gocui.KeyCtrlY:
+		for _, r := range e.kill {
+			v.EditWrite(r)
+		}
+	case key == gocui.KeyCtrlR:
+		if e.history != nil {
+			e.startSearch(v)
+		}
+	case key == gocui.KeyTab:
+		e.complete(v)
+	case key == gocui.KeyEnter:
+		done = true
+	case key == gocui.KeyEsc || key == gocui.KeyCtrlG:
+		cancel = true
+	case key == gocui.KeyArrowDown:
+		e.historyNext(v)
+	case key == gocui.KeyArrowUp:
+		e.historyPrev(v)
+	case key == gocui.KeyArrowLeft:
+		if cx > e.offset {
+			v.MoveCursor(-1, 0, false)
+		}
+	case key == gocui.KeyArrowRight:
+		v.MoveCursor(1, 0, false)
+	default:
+		consumed = false
+	}
+
+	if done || cancel {
+		content := e.content(v)
+		if done && e.history != nil && content != "" {
+			e.history.Add(content)
+		}
+		if e.history != nil {
+			e.history.Reset()
+		}
+		e.callback(done, content)
+	} else if consumed && e.onChange != nil {
+		e.onChange(e.content(v))
+	}
+
+	return consumed
+}
+
+func (e *LineEditor) lineAndCursor(v *gocui.View) (string, int) {
+	cx, _ := v.Cursor()
+	return getFirstLine(v.Buffer()), cx
+}
+
+func (e *LineEditor) content(v *gocui.View) string {
+	line := strings.TrimSpace(getFirstLine(v.Buffer()))
+	offset := e.offset
+	if offset > len(line) {
+		offset = len(line)
+	}
+	return line[offset:]
+}
+
+func isWordRune(r rune) bool {
+	return r != ' ' && r != '\t'
+}
+
+// wordBack returns the byte offset of the start of the word before cursor
+// cx, skipping any run of spaces immediately to the left first.
+func (e *LineEditor) wordBack(line string, cx int) int {
+	i := cx
+	for i > e.offset && !isWordRune(rune(line[i-1])) {
+		i--
+	}
+	for i > e.offset && isWordRune(rune(line[i-1])) {
+		i--
+	}
+	return i
+}
+
+// wordForward returns the byte offset of the end of the word at or after
+// cursor cx, skipping any run of spaces immediately to the right first.
+func (e *LineEditor) wordForward(line string, cx int) int {
+	i := cx
+	for i < len(line) && !isWordRune(rune(line[i])) {
+		i++
+	}
+	for i < len(line) && isWordRune(rune(line[i])) {
+		i++
+	}
+	return i
+}
+
+// deleteRange deletes the bytes of the current line in [from, to), leaving
+// the cursor at from.
+func (e *LineEditor) deleteRange(v *gocui.View, from, to int) {
+	v.SetCursor(to, 0)
+	for i := to; i > from; i-- {
+		v.EditDelete(true)
+	}
+}
+
+func (e *LineEditor) historyPrev(v *gocui.View) {
+	if e.history == nil {
+		return
+	}
+	if s, ok := e.history.Prev(); ok {
+		e.replaceContent(v, s)
+	}
+}
+
+func (e *LineEditor) historyNext(v *gocui.View) {
+	if e.history == nil {
+		return
+	}
+	if s, ok := e.history.Next(); ok {
+		e.replaceContent(v, s)
+	}
+}
+
+// replaceContent replaces everything after e.offset with s.
+func (e *LineEditor) replaceContent(v *gocui.View, s string) {
+	line, _ := e.lineAndCursor(v)
+	e.deleteRange(v, e.offset, len(line))
+	for _, r := range s {
+		v.EditWrite(r)
+	}
+}
+
+func (e *LineEditor) resetCompletion() {
+	e.completions = nil
+	e.completionIdx = 0
+}
+
+func (e *LineEditor) complete(v *gocui.View) {
+	if e.completer == nil {
+		return
+	}
+
+	if len(e.completions) == 0 {
+		line, cx := e.lineAndCursor(v)
+		completions, replaceFrom := e.completer(line, cx)
+		if len(completions) == 0 {
+			return
+		}
+		if len(completions) == 1 {
+			e.deleteRange(v, replaceFrom, cx)
+			for _, r := range completions[0] {
+				v.EditWrite(r)
+			}
+			return
+		}
+		e.completions = completions
+		e.completionIdx = 0
+		e.completionAt = replaceFrom
+	} else {
+		e.completionIdx = (e.completionIdx + 1) % len(e.completions)
+	}
+
+	_, cx := e.lineAndCursor(v)
+	e.deleteRange(v, e.completionAt, cx)
+	for _, r := range e.completions[e.completionIdx] {
+		v.EditWrite(r)
+	}
+}
+
+func (e *LineEditor) startSearch(v *gocui.View) {
+	line, _ := e.lineAndCursor(v)
+	prefix := line
+	if e.offset <= len(prefix) {
+		prefix = prefix[:e.offset]
+	}
+
+	e.searching = true
+	e.searchQuery = ""
+	e.searchPrefix = prefix
+	e.searchOrig = e.content(v)
+	e.searchSaved = e.searchOrig
+	e.history.Reset()
+	e.renderSearch(v, e.searchSaved)
+}
+
+func (e *LineEditor) editSearch(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) bool {
+	switch {
+	case ch != 0 && mod == 0:
+		e.searchQuery += string(ch)
+		e.searchStep(v, false)
+	case key == gocui.KeyBackspace || key == gocui.KeyBackspace2:
+		if len(e.searchQuery) > 0 {
+			e.searchQuery = e.searchQuery[:len(e.searchQuery)-1]
+		}
+		e.searchStep(v, false)
+	case key == gocui.KeyCtrlR:
+		// Repeat: the displayed match stays valid for the (unchanged) query,
+		// so explicitly skip it and look for the next older one.
+		e.searchStep(v, true)
+	case key == gocui.KeyEnter:
+		e.endSearch(v, true)
+	case key == gocui.KeyEsc || key == gocui.KeyCtrlG:
+		e.endSearch(v, false)
+	default:
+		e.endSearch(v, false)
+		return false
+	}
+
+	return true
+}
+
+// searchStep re-evaluates the current search match against searchQuery. If
+// the currently displayed match still satisfies the query (true right after
+// Backspace whenever it matched the longer query, since shrinking the query
+// can't invalidate a match) it's kept as-is; otherwise, and whenever advance is true (C-r),
+// it walks further back through history for the next match.
+func (e *LineEditor) searchStep(v *gocui.View, advance bool) {
+	match := e.searchSaved
+
+	if advance || !strings.Contains(match, e.searchQuery) {
+		for {
+			s, ok := e.history.Prev()
+			if !ok {
+				break
+			}
+			if strings.Contains(s, e.searchQuery) {
+				match = s
+				break
+			}
+		}
+	}
+
+	e.renderSearch(v, match)
+}
+
+func (e *LineEditor) renderSearch(v *gocui.View, match string) {
+	v.Clear()
+	fmt.Fprintf(v, "(reverse-i-search)`%s': %s", e.searchQuery, match)
+	e.searchSaved = match
+}
+
+func (e *LineEditor) endSearch(v *gocui.View, accept bool) {
+	e.searching = false
+
+	content := e.searchSaved
+	if !accept {
+		content = e.searchOrig
+	}
+
+	v.Clear()
+	fmt.Fprintf(v, "%s", e.searchPrefix)
+	for _, r := range content {
+		v.EditWrite(r)
+	}
+}
+
+func getFirstLine(s string) string {
+	idx := strings.Index(s, "\n")
+
+	if idx >= 0 {
+		return s[:idx]
+	}
+
+	return s
+}
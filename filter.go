@@ -0,0 +1,227 @@
+package xui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/tomyl/gocui"
+)
+
+// ListWidget filter actions
+const (
+	ActionStartFilter = "start_filter"
+	ActionClearFilter = "clear_filter"
+)
+
+// A Matcher scores how well item matches query. ok is false if item should
+// be excluded entirely. positions are the indices (in runes) of item that
+// matched query, used to highlight the match.
+type Matcher func(item, query string) (score int, positions []int, ok bool)
+
+// SetMatcher installs a custom Matcher, replacing the default FuzzyMatch.
+func (w *ListWidget) SetMatcher(m Matcher) {
+	w.matcher = m
+}
+
+// SetFilterOverlay configures the TextWidget used as a prompt overlay by
+// ActionStartFilter. g is the gocui.Gui the overlay's view belongs to.
+func (w *ListWidget) SetFilterOverlay(g *gocui.Gui, overlay *TextWidget) {
+	w.filterGui = g
+	w.filterOverlay = overlay
+}
+
+// SetFilter applies query as an incremental fuzzy filter over the model,
+// keeping the current selection if it still matches.
+func (w *ListWidget) SetFilter(query string) {
+	w.filterQuery = query
+	w.applyFilter()
+}
+
+// ClearFilter removes any active filter, restoring the full model.
+func (w *ListWidget) ClearFilter() {
+	w.filterQuery = ""
+	w.applyFilter()
+}
+
+func (w *ListWidget) applyFilter() {
+	prevModel := w.Current()
+
+	if w.filterQuery == "" {
+		w.filterIdx = nil
+		w.filterPositions = nil
+	} else {
+		matcher := w.matcher
+		if matcher == nil {
+			matcher = FuzzyMatch
+		}
+
+		type match struct {
+			idx       int
+			score     int
+			positions []int
+		}
+
+		var matches []match
+		for i, item := range w.model {
+			score, positions, ok := matcher(item, w.filterQuery)
+			if ok {
+				matches = append(matches, match{i, score, positions})
+			}
+		}
+
+		sort.SliceStable(matches, func(a, b int) bool {
+			if matches[a].score != matches[b].score {
+				return matches[a].score > matches[b].score
+			}
+			return matches[a].idx < matches[b].idx
+		})
+
+		idx := make([]int, len(matches))
+		positions := make(map[int][]int, len(matches))
+		for visible, m := range matches {
+			idx[visible] = m.idx
+			positions[m.idx] = m.positions
+		}
+		w.filterIdx = idx
+		w.filterPositions = positions
+	}
+
+	w.base.SetMax(len(w.visibleRows()))
+
+	if prevModel >= 0 && w.SetCurrent(prevModel) != nil {
+		w.base.SetCurrent(0)
+	}
+
+	w.render()
+}
+
+func (w *ListWidget) startFilter() error {
+	if w.filterOverlay == nil || w.filterGui == nil {
+		return Error("no filter overlay configured")
+	}
+
+	view := w.filterOverlay.View()
+	if view == nil {
+		return Error("filter overlay has no view")
+	}
+
+	g := w.filterGui
+	gx := New(g)
+	oldfocus := g.CurrentView()
+	g.Cursor = true
+	gx.Focus(view)
+
+	prefix := "/"
+	content := w.filterQuery
+	w.filterOverlay.SetText(prefix + content)
+
+	editor := NewLineEditor(g, len(prefix), func(success bool, response string) {
+		w.filterOverlay.setEditor(nil)
+		gx.Focus(oldfocus)
+		g.Cursor = false
+		if success {
+			w.SetFilter(response)
+		} else {
+			w.ClearFilter()
+		}
+	})
+	editor.SetOnChange(w.SetFilter)
+	w.filterOverlay.setEditor(editor)
+
+	return view.SetCursor(len(prefix)+len(content), 0)
+}
+
+// highlightLine builds a StyledLine for text with the runes at positions
+// rendered bold.
+func highlightLine(text string, positions []int) StyledLine {
+	if len(positions) == 0 {
+		return StyledLine{{Text: text}}
+	}
+
+	matched := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		matched[p] = true
+	}
+
+	var line StyledLine
+	var cur strings.Builder
+	bold := false
+	first := true
+
+	for i, r := range []rune(text) {
+		b := matched[i]
+		if first {
+			bold = b
+			first = false
+		} else if b != bold {
+			line = append(line, Segment{Text: cur.String(), Bold: bold})
+			cur.Reset()
+			bold = b
+		}
+		cur.WriteRune(r)
+	}
+	if cur.Len() > 0 {
+		line = append(line, Segment{Text: cur.String(), Bold: bold})
+	}
+
+	return line
+}
+
+// FuzzyMatch is the default Matcher. It walks query left-to-right as a
+// subsequence of item (case-insensitively), awarding bonus points for
+// consecutive matches and matches at word boundaries (after a space, '_',
+// '/', or a lower-to-upper case change). It rejects item unless every rune
+// of query is matched in order.
+func FuzzyMatch(item, query string) (int, []int, bool) {
+	if query == "" {
+		return 0, nil, true
+	}
+
+	src := []rune(item)
+	lower := []rune(strings.ToLower(item))
+	q := []rune(strings.ToLower(query))
+
+	var positions []int
+	score := 0
+	qi := 0
+	prev := -2
+
+	for i := 0; i < len(lower) && qi < len(q); i++ {
+		if lower[i] != q[qi] {
+			continue
+		}
+
+		points := 1
+		if i == prev+1 {
+			points += 4
+		}
+		if isWordBoundary(src, i) {
+			points += 3
+		}
+
+		score += points
+		positions = append(positions, i)
+		prev = i
+		qi++
+	}
+
+	if qi < len(q) {
+		return 0, nil, false
+	}
+
+	return score, positions, true
+}
+
+func isWordBoundary(src []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+
+	prev := src[i-1]
+	if prev == ' ' || prev == '_' || prev == '/' {
+		return true
+	}
+
+	return unicode.IsUpper(src[i]) && !unicode.IsUpper(prev)
+}
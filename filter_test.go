@@ -0,0 +1,67 @@
+package xui
+
+import "testing"
+
+func TestFuzzyMatch(t *testing.T) {
+	score, positions, ok := FuzzyMatch("main.go", "mg")
+	if !ok {
+		t.Fatalf("FuzzyMatch(%q, %q) = _, _, false, want true", "main.go", "mg")
+	}
+	if len(positions) != 2 || positions[0] != 0 || positions[1] != 5 {
+		t.Errorf("positions = %v, want [0 5]", positions)
+	}
+	if score <= 0 {
+		t.Errorf("score = %d, want > 0", score)
+	}
+
+	if _, _, ok := FuzzyMatch("main.go", "xyz"); ok {
+		t.Errorf("FuzzyMatch(%q, %q) matched, want no match", "main.go", "xyz")
+	}
+
+	if _, _, ok := FuzzyMatch("", "x"); ok {
+		t.Errorf("FuzzyMatch(%q, %q) matched, want no match", "", "x")
+	}
+
+	if _, _, ok := FuzzyMatch("anything", ""); !ok {
+		t.Errorf("FuzzyMatch with an empty query should always match")
+	}
+}
+
+func TestFuzzyMatchScoring(t *testing.T) {
+	// "ab" scores higher in "ab_main.go" (consecutive + word-boundary bonus)
+	// than in "a_b_main.go" (no consecutive match).
+	consecutive, _, ok := FuzzyMatch("ab_main.go", "ab")
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	split, _, ok := FuzzyMatch("a_b_main.go", "ab")
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if consecutive <= split {
+		t.Errorf("consecutive match score %d should exceed split match score %d", consecutive, split)
+	}
+}
+
+func TestIsWordBoundary(t *testing.T) {
+	tests := []struct {
+		s    string
+		i    int
+		want bool
+	}{
+		{"foo", 0, true},
+		{"foo_bar", 4, true},
+		{"foo/bar", 4, true},
+		{"foo bar", 4, true},
+		{"fooBar", 3, true},
+		{"foobar", 3, false},
+		{"FOOBar", 3, false},
+	}
+
+	for _, tt := range tests {
+		src := []rune(tt.s)
+		if got := isWordBoundary(src, tt.i); got != tt.want {
+			t.Errorf("isWordBoundary(%q, %d) = %v, want %v", tt.s, tt.i, got, tt.want)
+		}
+	}
+}
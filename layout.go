@@ -0,0 +1,187 @@
+package xui
+
+import "github.com/tomyl/gocui"
+
+// A Layout describes how the terminal is partitioned into views. Leaves are
+// built with Leaf and bind a Widget to a view name; HSplit and VSplit
+// arrange children along an axis, stacking them top-to-bottom or
+// left-to-right respectively.
+//
+// Each node accepts a Weight used to distribute space left over after
+// Fixed-size siblings are subtracted, a Fixed size in cells that takes
+// priority over Weight, and a MinSize floor applied to weighted nodes.
+type Layout struct {
+	Name   string
+	Widget Widget
+
+	Weight  float64
+	Fixed   int
+	MinSize int
+
+	horizontal bool
+	children   []Layout
+}
+
+// Leaf builds a Layout node binding widget to a view with the given name.
+func Leaf(name string, widget Widget) Layout {
+	return Layout{Name: name, Widget: widget}
+}
+
+// HSplit arranges children stacked top-to-bottom.
+func HSplit(children ...Layout) Layout {
+	return Layout{horizontal: true, children: children}
+}
+
+// VSplit arranges children side-by-side, left-to-right.
+func VSplit(children ...Layout) Layout {
+	return Layout{children: children}
+}
+
+// WithWeight returns a copy of l with Weight set, for use with weighted
+// allocation among siblings that don't specify Fixed.
+func (l Layout) WithWeight(weight float64) Layout {
+	l.Weight = weight
+	return l
+}
+
+// WithFixed returns a copy of l with Fixed set to a size in cells along the
+// parent's split axis, taking priority over Weight.
+func (l Layout) WithFixed(cells int) Layout {
+	l.Fixed = cells
+	return l
+}
+
+// WithMinSize returns a copy of l with MinSize set, a floor applied when the
+// node is allocated space by Weight.
+func (l Layout) WithMinSize(cells int) Layout {
+	l.MinSize = cells
+	return l
+}
+
+// Find returns the Widget bound to the named leaf, or nil if no such leaf
+// exists in the tree.
+func (l Layout) Find(name string) Widget {
+	if l.Name != "" && l.Name == name {
+		return l.Widget
+	}
+	for _, c := range l.children {
+		if w := c.Find(name); w != nil {
+			return w
+		}
+	}
+	return nil
+}
+
+func (l Layout) hasView(name string) bool {
+	if l.Name != "" && l.Name == name {
+		return true
+	}
+	for _, c := range l.children {
+		if c.hasView(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l Layout) apply(gx *Xui, r Region) error {
+	if l.Name != "" {
+		view := gx.SetRegionView(l.Name, r)
+		if l.Widget != nil {
+			l.Widget.SetView(view)
+		}
+		return gx.err
+	}
+
+	if len(l.children) == 0 {
+		return nil
+	}
+
+	if l.horizontal {
+		return l.applyAxis(gx, r.Top, r.Bottom, func(a, b int) Region {
+			return Region{Left: r.Left, Top: a, Right: r.Right, Bottom: b}
+		})
+	}
+
+	return l.applyAxis(gx, r.Left, r.Right, func(a, b int) Region {
+		return Region{Left: a, Top: r.Top, Right: b, Bottom: r.Bottom}
+	})
+}
+
+// applyAxis partitions the inclusive cell range [start, end] among
+// l.children and applies each at its allotted position along the axis.
+func (l Layout) applyAxis(gx *Xui, start, end int, mk func(a, b int) Region) error {
+	sizes := axisSizes(l.children, end-start+1)
+	pos := start
+
+	for i, c := range l.children {
+		next := pos + sizes[i] - 1
+		if err := c.apply(gx, mk(pos, next)); err != nil {
+			return err
+		}
+		pos = next + 1
+	}
+
+	return nil
+}
+
+// axisSizes computes each child's size along a total cell count, giving
+// Fixed-size children priority and splitting what remains among the rest by
+// Weight (floored at MinSize). Flooring the weighted shares can leave cells
+// unassigned, so whatever's left over is added to the last non-Fixed
+// child's share — wherever it falls in the list — so the sizes sum to
+// exactly total with no gap whenever at least one child is not Fixed and
+// the Fixed sizes and MinSize floors fit within total. If MinSize floors
+// overrun remaining, the leftover goes negative; it's clamped at 0 rather
+// than handed to apply() as a negative size, and the sizes then sum to more
+// than total.
+func axisSizes(children []Layout, total int) []int {
+	sizes := make([]int, len(children))
+
+	var fixedSum int
+	var weightSum float64
+	lastWeighted := -1
+
+	for i, c := range children {
+		if c.Fixed > 0 {
+			sizes[i] = c.Fixed
+			fixedSum += c.Fixed
+		} else {
+			weightSum += c.Weight
+			lastWeighted = i
+		}
+	}
+
+	remaining := maxInt(0, total-fixedSum)
+	used := 0
+
+	for i, c := range children {
+		if c.Fixed > 0 {
+			continue
+		}
+		if weightSum > 0 {
+			sizes[i] = maxInt(c.MinSize, int(float64(remaining)*c.Weight/weightSum))
+		} else {
+			sizes[i] = c.MinSize
+		}
+		used += sizes[i]
+	}
+
+	if lastWeighted >= 0 {
+		sizes[lastWeighted] = maxInt(0, sizes[lastWeighted]+remaining-used)
+	}
+
+	return sizes
+}
+
+// ApplyLayout lays out root within the terminal and returns a gocui manager
+// function that re-applies the layout only when the terminal is resized.
+// Once set, FocusName only succeeds for view names present in root.
+func (gx *Xui) ApplyLayout(root Layout) func(*gocui.Gui) error {
+	gx.layout = &root
+
+	return ResizeLayout(func(g *gocui.Gui) error {
+		maxX, maxY := g.Size()
+		return root.apply(gx, Region{Left: 0, Top: 0, Right: maxX - 1, Bottom: maxY - 1})
+	})
+}
@@ -0,0 +1,117 @@
+package xui
+
+import "testing"
+
+func sumSizes(sizes []int) int {
+	n := 0
+	for _, s := range sizes {
+		n += s
+	}
+	return n
+}
+
+func TestAxisSizesEqualWeightsCoverTotal(t *testing.T) {
+	children := []Layout{
+		Leaf("a", nil).WithWeight(1),
+		Leaf("b", nil).WithWeight(1),
+		Leaf("c", nil).WithWeight(1),
+	}
+
+	sizes := axisSizes(children, 80)
+	if got := sumSizes(sizes); got != 80 {
+		t.Errorf("sum of sizes = %d, want 80 (no gap from floor truncation)", got)
+	}
+	for i, s := range sizes {
+		if s < 26 {
+			t.Errorf("sizes[%d] = %d, want at least the floored equal share (26)", i, s)
+		}
+	}
+}
+
+func TestAxisSizesFixedTakesPriority(t *testing.T) {
+	children := []Layout{
+		Leaf("fixed", nil).WithFixed(10),
+		Leaf("weighted", nil).WithWeight(1),
+	}
+
+	sizes := axisSizes(children, 30)
+	if sizes[0] != 10 {
+		t.Errorf("fixed child size = %d, want 10", sizes[0])
+	}
+	if sizes[1] != 20 {
+		t.Errorf("weighted child size = %d, want 20 (30 - fixed 10)", sizes[1])
+	}
+}
+
+func TestAxisSizesRemainderGoesToLastWeightedRegardlessOfPosition(t *testing.T) {
+	// The fixed child sits after the weighted children in the list; the last
+	// weighted child must still absorb the remainder (17 cells floored to
+	// 8 + 8) without the fixed child's own size being touched.
+	children := []Layout{
+		Leaf("weighted-a", nil).WithWeight(1),
+		Leaf("weighted-b", nil).WithWeight(1),
+		Leaf("fixed", nil).WithFixed(7),
+	}
+
+	sizes := axisSizes(children, 24)
+	if sizes[2] != 7 {
+		t.Errorf("fixed child size = %d, want 7 regardless of remainder distribution", sizes[2])
+	}
+	if sizes[1] != 9 {
+		t.Errorf("last weighted child size = %d, want 9 (floored share 8 plus remainder 1)", sizes[1])
+	}
+	if got := sumSizes(sizes); got != 24 {
+		t.Errorf("sum of sizes = %d, want 24", got)
+	}
+}
+
+func TestAxisSizesMinSizeFloor(t *testing.T) {
+	children := []Layout{
+		Leaf("a", nil).WithWeight(100).WithMinSize(5),
+		Leaf("b", nil).WithWeight(1).WithMinSize(5),
+	}
+
+	sizes := axisSizes(children, 1000)
+	if sizes[1] < 5 {
+		t.Errorf("sizes[1] = %d, want at least MinSize 5", sizes[1])
+	}
+}
+
+func TestAxisSizesMinSizeFloorOverrunsTotalClampsAtZero(t *testing.T) {
+	children := []Layout{
+		Leaf("a", nil).WithWeight(1).WithMinSize(50),
+		Leaf("b", nil).WithWeight(1).WithMinSize(50),
+		Leaf("c", nil).WithWeight(1).WithMinSize(50),
+	}
+
+	sizes := axisSizes(children, 60)
+	for i, s := range sizes {
+		if s < 0 {
+			t.Errorf("sizes[%d] = %d, want clamped at 0", i, s)
+		}
+	}
+}
+
+func TestLayoutFind(t *testing.T) {
+	listWidget := &ListWidget{}
+	tree := HSplit(
+		Leaf("left", nil).WithWeight(1),
+		VSplit(
+			Leaf("right-top", listWidget).WithWeight(1),
+			Leaf("right-bottom", nil).WithWeight(1),
+		).WithWeight(1),
+	)
+
+	if w := tree.Find("right-top"); w != listWidget {
+		t.Errorf("Find(%q) = %v, want the bound widget", "right-top", w)
+	}
+	if w := tree.Find("missing"); w != nil {
+		t.Errorf("Find(%q) = %v, want nil", "missing", w)
+	}
+	if !tree.hasView("left") {
+		t.Errorf("hasView(%q) = false, want true", "left")
+	}
+	if tree.hasView("missing") {
+		t.Errorf("hasView(%q) = true, want false", "missing")
+	}
+}
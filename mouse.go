@@ -0,0 +1,114 @@
+package xui
+
+import (
+	"errors"
+
+	"github.com/tomyl/gocui"
+)
+
+// currenter is implemented by widgets (ScrollWidget, ListWidget, TableWidget,
+// ...) that support selecting a row by absolute model index.
+type currenter interface {
+	SetCurrent(int) error
+}
+
+// rowMapper is implemented by widgets (ListWidget) whose visible rows don't
+// map 1:1 onto the model index SetCurrent expects, e.g. while a filter is
+// active. ModelIndex translates a visible row (as reported by GetLine) into
+// its model index, or -1 if the row isn't currently visible.
+type rowMapper interface {
+	ModelIndex(int) int
+}
+
+// EnableMouse turns on mouse support and registers default bindings:
+// clicking inside a registered widget's view focuses it and, if the widget
+// supports row selection, selects the clicked row; the wheel scrolls the
+// widget whose view is under the pointer by line. gocui's termbox backend reports no shift modifier
+// for mouse events, so there's no page-at-a-time wheel variant.
+func (gx *Xui) EnableMouse() {
+	if gx.err != nil {
+		return
+	}
+
+	gx.g.Mouse = true
+
+	gx.g.SetKeybinding("", gocui.MouseLeft, gocui.ModNone, gx.handleMouseClick)
+	gx.g.SetKeybinding("", gocui.MouseWheelUp, gocui.ModNone, gx.handleWheel(ActionPreviousLine))
+	gx.g.SetKeybinding("", gocui.MouseWheelDown, gocui.ModNone, gx.handleWheel(ActionNextLine))
+}
+
+func (gx *Xui) handleMouseClick(g *gocui.Gui, v *gocui.View) error {
+	if v == nil {
+		return nil
+	}
+
+	gx.callPreActionHandler()
+	gx.FocusName(v.Name())
+
+	var err error
+	if widget, ok := gx.widgets[v.Name()]; ok {
+		if s, ok := widget.(currenter); ok {
+			row := GetLine(v)
+			if m, ok := widget.(rowMapper); ok {
+				row = m.ModelIndex(row)
+			}
+			if row >= 0 {
+				err = s.SetCurrent(row)
+				if err != nil {
+					err = ErrAction{viewname: v.Name(), err: err}
+				}
+			}
+		}
+	}
+
+	return gx.callPostActionHandler(err)
+}
+
+func (gx *Xui) handleWheel(action string) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if v == nil {
+			return nil
+		}
+
+		gx.callPreActionHandler()
+
+		var err error
+		if widget, ok := gx.widgets[v.Name()]; ok {
+			err = widget.HandleAction(action)
+			if e, ok := err.(ErrAction); ok {
+				e.viewname = v.Name()
+				e.action = action
+				err = e
+			}
+		}
+
+		return gx.callPostActionHandler(err)
+	}
+}
+
+// SetWidgetMouseBinding binds a mouse button (and modifier, e.g. for
+// shift-click or shift-wheel) on a widget's view to handler, invoked with
+// the model row index the click or wheel event landed on.
+func (gx *Xui) SetWidgetMouseBinding(widget Widget, button gocui.Key, mod gocui.Modifier, handler func(row int) error) {
+	if gx.err == nil {
+		view := widget.View()
+		if view == nil {
+			gx.err = errors.New("widget has no view")
+		} else {
+			gx.registerWidget(view.Name(), widget)
+			gx.err = gx.g.SetKeybinding(view.Name(), button, mod,
+				func(g *gocui.Gui, v *gocui.View) error {
+					gx.callPreActionHandler()
+					row := GetLine(v)
+					if m, ok := widget.(rowMapper); ok {
+						row = m.ModelIndex(row)
+					}
+					err := handler(row)
+					if err != nil {
+						err = ErrAction{viewname: view.Name(), err: err}
+					}
+					return gx.callPostActionHandler(err)
+				})
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package xui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tomyl/gocui"
+)
+
+// keyBinding records a labeled keybinding so it can be surfaced by a
+// StatusBar or the key cheatsheet popup.
+type keyBinding struct {
+	viewname string
+	key      interface{}
+	mod      gocui.Modifier
+	label    string
+}
+
+func (b keyBinding) keyString() string {
+	switch k := b.key.(type) {
+	case gocui.Key:
+		if s, ok := keyNames[k]; ok {
+			return s
+		}
+		return fmt.Sprintf("<%d>", k)
+	case rune:
+		return string(k)
+	default:
+		return fmt.Sprintf("%v", k)
+	}
+}
+
+// keyNames maps the non-printable gocui.Key values used elsewhere in this
+// package to short display names.
+var keyNames = map[gocui.Key]string{
+	gocui.KeyCtrlA:      "C-a",
+	gocui.KeyCtrlE:      "C-e",
+	gocui.KeyCtrlK:      "C-k",
+	gocui.KeyCtrlR:      "C-r",
+	gocui.KeyCtrlU:      "C-u",
+	gocui.KeyCtrlW:      "C-w",
+	gocui.KeyCtrlY:      "C-y",
+	gocui.KeyCtrlG:      "C-g",
+	gocui.KeyEnter:      "enter",
+	gocui.KeyEsc:        "esc",
+	gocui.KeyTab:        "tab",
+	gocui.KeySpace:      "space",
+	gocui.KeyBackspace:  "bksp",
+	gocui.KeyBackspace2: "bksp",
+	gocui.KeyDelete:     "del",
+	gocui.KeyArrowUp:    "up",
+	gocui.KeyArrowDown:  "down",
+	gocui.KeyArrowLeft:  "left",
+	gocui.KeyArrowRight: "right",
+}
+
+func (gx *Xui) recordBinding(viewname string, key interface{}, mod gocui.Modifier, label string) {
+	gx.bindings = append(gx.bindings, keyBinding{viewname: viewname, key: key, mod: mod, label: label})
+}
+
+// bindingsFor returns the labeled bindings active for viewname, which
+// includes bindings registered for "" (all views).
+func (gx *Xui) bindingsFor(viewname string) []keyBinding {
+	var out []keyBinding
+	for _, b := range gx.bindings {
+		if b.viewname == viewname || b.viewname == "" {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// A StatusBar renders a one-line summary of the labeled keybindings active
+// for the currently focused view. NewStatusBar keeps it in sync by
+// installing its own focus change hook on gx, replacing any hook set before.
+type StatusBar struct {
+	view *gocui.View
+	gx   *Xui
+}
+
+// NewStatusBar builds a StatusBar and subscribes it to gx's focus changes.
+func NewStatusBar(gx *Xui) *StatusBar {
+	bar := &StatusBar{gx: gx}
+	gx.SetFocusChangeHook(bar.focus)
+	return bar
+}
+
+// View returns the gocui.View currently bound to this widget.
+func (w *StatusBar) View() *gocui.View {
+	return w.view
+}
+
+// SetView binds a gocui.View to this widget.
+func (w *StatusBar) SetView(view *gocui.View) {
+	view.Wrap = false
+	w.view = view
+	w.render()
+}
+
+// HandleAction executes an action command. StatusBar has no actions of its
+// own and always returns UnknownAction.
+func (w *StatusBar) HandleAction(action string) error {
+	return UnknownAction()
+}
+
+func (w *StatusBar) focus(viewname string) {
+	w.render()
+}
+
+func (w *StatusBar) render() {
+	if w.view == nil || w.gx == nil {
+		return
+	}
+
+	viewname := ""
+	if cur := w.gx.g.CurrentView(); cur != nil {
+		viewname = cur.Name()
+	}
+
+	var parts []string
+	for _, b := range w.gx.bindingsFor(viewname) {
+		parts = append(parts, fmt.Sprintf("%s: %s", b.keyString(), b.label))
+	}
+
+	sx, _ := w.view.Size()
+	w.view.Clear()
+	fmt.Fprintf(w.view, Pad(strings.Join(parts, "  "), sx))
+}
+
+// ShowCheatsheet renders a modal popup listing every labeled binding,
+// grouped by view, into a view named "cheatsheet" sized to fit the content
+// (or the terminal, if smaller). Bind this to "?" to let users discover
+// every available keybinding.
+func (gx *Xui) ShowCheatsheet() error {
+	lines := gx.cheatsheetLines()
+
+	maxX, maxY := gx.g.Size()
+	width := 0
+	for _, line := range lines {
+		if w := StringWidth(line); w > width {
+			width = w
+		}
+	}
+
+	width = minInt(width+2, maxX-4)
+	height := minInt(len(lines)+2, maxY-4)
+
+	x0 := (maxX - width) / 2
+	y0 := (maxY - height) / 2
+
+	view := gx.SetView("cheatsheet", x0, y0, x0+width+1, y0+height+1)
+	if view == nil {
+		return gx.err
+	}
+
+	view.Title = "Keybindings (press esc to close)"
+	view.Wrap = false
+	view.Clear()
+	for _, line := range lines {
+		fmt.Fprintln(view, line)
+	}
+
+	oldfocus := gx.g.CurrentView()
+
+	gx.SetViewOnTop("cheatsheet")
+	gx.SetCurrentView("cheatsheet")
+
+	// Closing deletes only the view, not its keybindings, so drop any
+	// binding left over from a previous open before registering a fresh
+	// one — gocui runs every matching binding on a view, not just the last.
+	gx.g.DeleteKeybindings("cheatsheet")
+	gx.err = gx.g.SetKeybinding("cheatsheet", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if err := g.DeleteView("cheatsheet"); err != nil {
+			return err
+		}
+		gx.Focus(oldfocus)
+		return nil
+	})
+
+	return gx.err
+}
+
+func (gx *Xui) cheatsheetLines() []string {
+	byView := make(map[string][]keyBinding)
+	var views []string
+	for _, b := range gx.bindings {
+		name := b.viewname
+		if name == "" {
+			name = "(global)"
+		}
+		if _, ok := byView[name]; !ok {
+			views = append(views, name)
+		}
+		byView[name] = append(byView[name], b)
+	}
+	sort.Strings(views)
+
+	var lines []string
+	for _, name := range views {
+		lines = append(lines, name+":")
+		for _, b := range byView[name] {
+			lines = append(lines, fmt.Sprintf("  %-10s %s", b.keyString(), b.label))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,246 @@
+package xui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tomyl/gocui"
+)
+
+// A Segment is a run of text sharing the same display attributes.
+type Segment struct {
+	Text      string
+	FgColor   gocui.Attribute
+	BgColor   gocui.Attribute
+	Bold      bool
+	Underline bool
+}
+
+// A StyledLine is a sequence of styled Segments making up a single line.
+type StyledLine []Segment
+
+// ansiSGRColor maps the color index 0-7 of the basic ANSI SGR color codes
+// (the code minus 30, 40, 90 or 100) to gocui attributes.
+var ansiSGRColor = map[int]gocui.Attribute{
+	0: gocui.ColorBlack,
+	1: gocui.ColorRed,
+	2: gocui.ColorGreen,
+	3: gocui.ColorYellow,
+	4: gocui.ColorBlue,
+	5: gocui.ColorMagenta,
+	6: gocui.ColorCyan,
+	7: gocui.ColorWhite,
+}
+
+// ParseANSI parses a string containing ANSI SGR escape sequences (e.g.
+// "\x1b[31;1mfoo\x1b[0m") into a StyledLine. CSI sequences other than SGR
+// (e.g. cursor movement) are dropped; for any other escape only the ESC
+// byte is dropped and the bytes after it are kept as literal text.
+func ParseANSI(s string) StyledLine {
+	var line StyledLine
+	fg := gocui.ColorDefault
+	bg := gocui.ColorDefault
+	bold := false
+	underline := false
+
+	flush := func(text string) {
+		if text != "" {
+			line = append(line, Segment{
+				Text:      text,
+				FgColor:   fg,
+				BgColor:   bg,
+				Bold:      bold,
+				Underline: underline,
+			})
+		}
+	}
+
+	for len(s) > 0 {
+		idx := strings.IndexByte(s, '\x1b')
+		if idx < 0 {
+			flush(s)
+			break
+		}
+
+		flush(s[:idx])
+		s = s[idx:]
+
+		end := reStripEscapeSeq.FindStringIndex(s)
+		if end == nil || end[0] != 0 {
+			// Not a recognized escape sequence; drop the ESC byte and continue.
+			s = s[1:]
+			continue
+		}
+
+		seq := s[:end[1]]
+		s = s[end[1]:]
+
+		if !strings.HasSuffix(seq, "m") {
+			// Not an SGR sequence (e.g. cursor movement); ignore it.
+			continue
+		}
+
+		params := seq[2 : len(seq)-1]
+		for _, p := range strings.Split(params, ";") {
+			if p == "" {
+				p = "0"
+			}
+			n, err := strconv.Atoi(p)
+			if err != nil {
+				continue
+			}
+			switch {
+			case n == 0:
+				fg = gocui.ColorDefault
+				bg = gocui.ColorDefault
+				bold = false
+				underline = false
+			case n == 1:
+				bold = true
+			case n == 4:
+				underline = true
+			case n == 22:
+				bold = false
+			case n == 24:
+				underline = false
+			case n == 39:
+				fg = gocui.ColorDefault
+			case n == 49:
+				bg = gocui.ColorDefault
+			case n >= 30 && n <= 37:
+				fg = ansiSGRColor[n-30]
+			case n >= 40 && n <= 47:
+				bg = ansiSGRColor[n-40]
+			case n >= 90 && n <= 97:
+				// gocui's 8-color model has no separate bright palette; bold
+				// is how terminals conventionally render a bright foreground.
+				fg = ansiSGRColor[n-90]
+				bold = true
+			case n >= 100 && n <= 107:
+				bg = ansiSGRColor[n-100]
+			}
+		}
+	}
+
+	return line
+}
+
+// Width returns the visible width of the line in single-width unicode
+// character units, ignoring any escape bytes.
+func (line StyledLine) Width() int {
+	w := 0
+	for _, seg := range line {
+		w += StringWidth(reStripEscapeSeq.ReplaceAllString(seg.Text, ""))
+	}
+	return w
+}
+
+// Pad appends a trailing segment of spaces so that the line's visible width
+// matches n. The padding segment uses the background of the last segment in
+// the line so that colored backgrounds extend to the column width.
+func (line StyledLine) Pad(n int) StyledLine {
+	w := line.Width()
+	if w >= n {
+		return line
+	}
+
+	bg := gocui.ColorDefault
+	if len(line) > 0 {
+		bg = line[len(line)-1].BgColor
+	}
+
+	return append(line, Segment{
+		Text:    strings.Repeat(" ", n-w),
+		BgColor: bg,
+	})
+}
+
+func (seg Segment) attribute() gocui.Attribute {
+	attr := seg.FgColor
+	if seg.Bold {
+		attr |= gocui.AttrBold
+	}
+	if seg.Underline {
+		attr |= gocui.AttrUnderline
+	}
+	return attr
+}
+
+// writeStyled writes a StyledLine to view, toggling the view's FgColor and
+// BgColor between segments and resetting attributes at the end of the line.
+func writeStyled(view *gocui.View, line StyledLine) {
+	for _, seg := range line {
+		view.FgColor = seg.attribute()
+		view.BgColor = seg.BgColor
+		fmt.Fprint(view, seg.Text)
+	}
+	view.FgColor = gocui.ColorDefault
+	view.BgColor = gocui.ColorDefault
+}
+
+// SetStyledText updates the widget to display a pre-styled line instead of a
+// plain string.
+func (w *TextWidget) SetStyledText(line StyledLine) {
+	w.text = ""
+	w.styled = line
+	w.render()
+}
+
+// A StyledListWidget displays a list of StyledLines, rendering each segment
+// with its own colors and attributes instead of a single view-wide color.
+type StyledListWidget struct {
+	Highlight bool
+
+	base  ScrollWidget
+	model []StyledLine
+}
+
+// View returns the gocui.View currently bound to this widget.
+func (w *StyledListWidget) View() *gocui.View {
+	return w.base.View()
+}
+
+// SetView binds a gocui.View to this widget.
+func (w *StyledListWidget) SetView(view *gocui.View) {
+	w.base.SetView(view)
+	w.base.Highlight = w.Highlight
+	w.render()
+}
+
+// SetModel updates the list of styled lines to display.
+func (w *StyledListWidget) SetModel(model []StyledLine) {
+	w.base.SetMax(len(model))
+	w.model = model
+	w.render()
+}
+
+// Current returns currently selected line.
+func (w *StyledListWidget) Current() int {
+	return w.base.Current()
+}
+
+// SetCurrent updates currently selected line.
+func (w *StyledListWidget) SetCurrent(idx int) error {
+	return w.base.SetCurrent(idx)
+}
+
+// HandleAction executes an action command.
+func (w *StyledListWidget) HandleAction(action string) error {
+	return w.base.HandleAction(action)
+}
+
+func (w *StyledListWidget) render() {
+	view := w.base.View()
+
+	if view != nil {
+		view.Clear()
+		sx, _ := view.Size()
+		for i, line := range w.model {
+			if i > 0 {
+				fmt.Fprintf(view, "\n")
+			}
+			writeStyled(view, line.Pad(sx))
+		}
+	}
+}
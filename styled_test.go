@@ -0,0 +1,79 @@
+package xui
+
+import (
+	"testing"
+
+	"github.com/tomyl/gocui"
+)
+
+func TestParseANSI(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want StyledLine
+	}{
+		{
+			name: "plain",
+			in:   "hello",
+			want: StyledLine{{Text: "hello", FgColor: gocui.ColorDefault, BgColor: gocui.ColorDefault}},
+		},
+		{
+			name: "fg and bold",
+			in:   "\x1b[31;1mfoo\x1b[0m",
+			want: StyledLine{{Text: "foo", FgColor: gocui.ColorRed, BgColor: gocui.ColorDefault, Bold: true}},
+		},
+		{
+			name: "bright fg",
+			in:   "\x1b[91mfoo\x1b[0m",
+			want: StyledLine{{Text: "foo", FgColor: gocui.ColorRed, BgColor: gocui.ColorDefault, Bold: true}},
+		},
+		{
+			name: "bright bg",
+			in:   "\x1b[104mfoo\x1b[0m",
+			want: StyledLine{{Text: "foo", FgColor: gocui.ColorDefault, BgColor: gocui.ColorBlue}},
+		},
+		{
+			name: "reset mid string",
+			in:   "\x1b[32mfoo\x1b[0mbar",
+			want: StyledLine{
+				{Text: "foo", FgColor: gocui.ColorGreen, BgColor: gocui.ColorDefault},
+				{Text: "bar", FgColor: gocui.ColorDefault, BgColor: gocui.ColorDefault},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseANSI(tt.in)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseANSI(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("segment %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStyledLinePad(t *testing.T) {
+	line := StyledLine{{Text: "ab", BgColor: gocui.ColorRed}}
+
+	padded := line.Pad(5)
+	if w := padded.Width(); w != 5 {
+		t.Fatalf("Width() = %d, want 5", w)
+	}
+	if len(padded) != 2 {
+		t.Fatalf("Pad() produced %d segments, want 2", len(padded))
+	}
+	if padded[1].BgColor != gocui.ColorRed {
+		t.Errorf("padding segment BgColor = %v, want %v", padded[1].BgColor, gocui.ColorRed)
+	}
+
+	// Already wide enough: Pad is a no-op.
+	same := padded.Pad(5)
+	if len(same) != len(padded) {
+		t.Errorf("Pad() on an already-wide line changed segment count: %d vs %d", len(same), len(padded))
+	}
+}
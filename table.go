@@ -0,0 +1,290 @@
+package xui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tomyl/gocui"
+)
+
+// Table column actions
+const (
+	ActionSortNext      = "sort_next"
+	ActionSortPrev      = "sort_prev"
+	ActionToggleSortDir = "toggle_sort_dir"
+)
+
+// Align specifies how a column's content is aligned within its width.
+type Align int
+
+// Supported column alignments.
+const (
+	Left Align = iota
+	Right
+	Center
+)
+
+// A Column describes a single column of a TableWidget.
+type Column struct {
+	Name     string
+	Width    int
+	MinWidth int
+	Weight   float64
+	Align    Align
+	Format   func(interface{}) string
+}
+
+// A TableWidget renders rows of cells under a sticky header, built on top of
+// ScrollWidget for vertical scrolling of the body.
+type TableWidget struct {
+	Highlight bool
+
+	// CellStyle returns the colors to use for a given cell, or ColorDefault
+	// for both if the cell has no special styling.
+	CellStyle func(row, col int) (fg, bg gocui.Attribute)
+
+	columns []Column
+	rows    [][]interface{}
+
+	header *gocui.View
+	base   ScrollWidget
+
+	sortCol int
+	sortAsc bool
+}
+
+// SetColumns updates the column definitions.
+func (w *TableWidget) SetColumns(columns []Column) {
+	w.columns = columns
+	w.sortCol = -1
+	w.renderHeader()
+	w.render()
+}
+
+// SetModel updates the rows to display. Each row is a slice of arbitrary
+// cell values formatted by the corresponding column's Format function.
+func (w *TableWidget) SetModel(rows [][]interface{}) {
+	w.rows = rows
+	w.base.SetMax(len(rows))
+	if w.sortCol >= 0 {
+		w.sort()
+	}
+	w.render()
+}
+
+// View returns the gocui.View currently bound to the scrollable body.
+func (w *TableWidget) View() *gocui.View {
+	return w.base.View()
+}
+
+// SetView binds a gocui.View to the scrollable body of this widget.
+func (w *TableWidget) SetView(view *gocui.View) {
+	w.base.SetView(view)
+	w.base.Highlight = w.Highlight
+	w.render()
+}
+
+// SetHeaderView binds a gocui.View to the sticky header row.
+func (w *TableWidget) SetHeaderView(view *gocui.View) {
+	w.header = view
+	w.renderHeader()
+}
+
+// Current returns the currently selected row.
+func (w *TableWidget) Current() int {
+	return w.base.Current()
+}
+
+// SetCurrent updates the currently selected row.
+func (w *TableWidget) SetCurrent(idx int) error {
+	return w.base.SetCurrent(idx)
+}
+
+// SetSort sorts the rows by the given column, ascending or descending.
+func (w *TableWidget) SetSort(colIdx int, asc bool) {
+	w.sortCol = colIdx
+	w.sortAsc = asc
+	w.sort()
+	w.render()
+}
+
+// HandleAction executes an action command, delegating scrolling actions to
+// the embedded ScrollWidget and handling sort actions locally.
+func (w *TableWidget) HandleAction(action string) error {
+	switch action {
+	case ActionSortNext:
+		w.SetSort(minInt(w.sortCol+1, len(w.columns)-1), w.sortAsc)
+		return nil
+	case ActionSortPrev:
+		w.SetSort(maxInt(w.sortCol-1, 0), w.sortAsc)
+		return nil
+	case ActionToggleSortDir:
+		w.SetSort(w.sortCol, !w.sortAsc)
+		return nil
+	default:
+		return w.base.HandleAction(action)
+	}
+}
+
+func (w *TableWidget) sort() {
+	if w.sortCol < 0 || w.sortCol >= len(w.columns) {
+		return
+	}
+
+	sort.SliceStable(w.rows, func(i, j int) bool {
+		a := w.cellText(w.rows[i], w.sortCol)
+		b := w.cellText(w.rows[j], w.sortCol)
+		if w.sortAsc {
+			return a < b
+		}
+		return a > b
+	})
+}
+
+func (w *TableWidget) cellText(row []interface{}, colIdx int) string {
+	var v interface{}
+	if colIdx < len(row) {
+		v = row[colIdx]
+	}
+	if col := w.columns[colIdx]; col.Format != nil {
+		return col.Format(v)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// widths computes the width of each column for the given terminal width,
+// applying the weighted allocation after subtracting fixed-width columns and
+// flooring at MinWidth. Flooring the weighted shares can leave columns
+// unassigned, so whatever's left over is added to the last weighted column's
+// share, the same remainder-distribution axisSizes uses for Layout splits.
+func (w *TableWidget) widths(total int) []int {
+	widths := make([]int, len(w.columns))
+	var fixedSum int
+	var weightSum float64
+	lastWeighted := -1
+
+	for i, col := range w.columns {
+		if col.Width > 0 {
+			widths[i] = col.Width
+			fixedSum += col.Width
+		} else {
+			weightSum += col.Weight
+			lastWeighted = i
+		}
+	}
+
+	remaining := maxInt(0, total-fixedSum)
+	used := 0
+
+	for i, col := range w.columns {
+		if col.Width > 0 {
+			continue
+		}
+		if weightSum > 0 {
+			widths[i] = maxInt(col.MinWidth, int(float64(remaining)*col.Weight/weightSum))
+		} else {
+			widths[i] = col.MinWidth
+		}
+		used += widths[i]
+	}
+
+	if lastWeighted >= 0 {
+		widths[lastWeighted] = maxInt(0, widths[lastWeighted]+remaining-used)
+	}
+
+	return widths
+}
+
+func (w *TableWidget) renderHeader() {
+	if w.header == nil {
+		return
+	}
+
+	w.header.Clear()
+	sx, _ := w.header.Size()
+	widths := w.widths(sx)
+
+	var b strings.Builder
+	for i, col := range w.columns {
+		name := col.Name
+		if i == w.sortCol {
+			if w.sortAsc {
+				name += " ^"
+			} else {
+				name += " v"
+			}
+		}
+		b.WriteString(formatCell(name, widths[i], col.Align))
+	}
+	fmt.Fprint(w.header, b.String())
+}
+
+func (w *TableWidget) render() {
+	view := w.base.View()
+	if view == nil {
+		return
+	}
+
+	view.Clear()
+	sx, _ := view.Size()
+	widths := w.widths(sx)
+
+	for r, row := range w.rows {
+		if r > 0 {
+			fmt.Fprintf(view, "\n")
+		}
+
+		var line StyledLine
+		for c, col := range w.columns {
+			text := formatCell(w.cellText(row, c), widths[c], col.Align)
+			fg, bg := gocui.ColorDefault, gocui.ColorDefault
+			if w.CellStyle != nil {
+				fg, bg = w.CellStyle(r, c)
+			}
+			line = append(line, Segment{Text: text, FgColor: fg, BgColor: bg})
+		}
+		writeStyled(view, line.Pad(sx))
+	}
+}
+
+// formatCell truncates text with an ellipsis if it exceeds width and pads or
+// aligns it to fill the column.
+func formatCell(text string, width int, align Align) string {
+	w := StringWidth(text)
+
+	if w > width {
+		if width <= 1 {
+			return strings.Repeat(".", width)
+		}
+		text = runeSlice(text, width-1) + "…"
+		w = width
+	}
+
+	pad := width - w
+
+	switch align {
+	case Right:
+		return strings.Repeat(" ", pad) + text
+	case Center:
+		left := pad / 2
+		right := pad - left
+		return strings.Repeat(" ", left) + text + strings.Repeat(" ", right)
+	default:
+		return text + strings.Repeat(" ", pad)
+	}
+}
+
+// runeSlice returns the first n single-width units of s, counted the same
+// way as StringWidth.
+func runeSlice(s string, n int) string {
+	w := 0
+	for i, ch := range s {
+		rw := StringWidth(string(ch))
+		if w+rw > n {
+			return s[:i]
+		}
+		w += rw
+	}
+	return s
+}
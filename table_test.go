@@ -0,0 +1,65 @@
+package xui
+
+import "testing"
+
+func TestTableWidgetWidths(t *testing.T) {
+	w := &TableWidget{}
+	w.SetColumns([]Column{
+		{Name: "a", Weight: 1},
+		{Name: "b", Weight: 1},
+		{Name: "c", Weight: 1},
+	})
+
+	widths := w.widths(80)
+	if len(widths) != 3 {
+		t.Fatalf("len(widths) = %d, want 3", len(widths))
+	}
+
+	sum := 0
+	for _, n := range widths {
+		sum += n
+	}
+	if sum != 80 {
+		t.Errorf("sum of equal-weight column widths = %d, want 80 (no gap from truncation)", sum)
+	}
+}
+
+func TestTableWidgetWidthsFixedAndMin(t *testing.T) {
+	w := &TableWidget{}
+	w.SetColumns([]Column{
+		{Name: "fixed", Width: 10},
+		{Name: "weighted", Weight: 1, MinWidth: 3},
+	})
+
+	widths := w.widths(20)
+	if widths[0] != 10 {
+		t.Errorf("fixed column width = %d, want 10", widths[0])
+	}
+	if widths[1] != 10 {
+		t.Errorf("weighted column width = %d, want 10 (20 - fixed 10)", widths[1])
+	}
+}
+
+func TestFormatCell(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		align Align
+		want  string
+	}{
+		{"left pad", "ab", 5, Left, "ab   "},
+		{"right align", "ab", 5, Right, "   ab"},
+		{"center align", "ab", 6, Center, "  ab  "},
+		{"truncate with ellipsis", "abcdef", 4, Left, "abc…"},
+		{"exact fit", "abcd", 4, Left, "abcd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCell(tt.text, tt.width, tt.align); got != tt.want {
+				t.Errorf("formatCell(%q, %d, %v) = %q, want %q", tt.text, tt.width, tt.align, got, tt.want)
+			}
+		})
+	}
+}
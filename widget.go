@@ -2,7 +2,6 @@ package xui
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/tomyl/gocui"
 )
@@ -11,13 +10,15 @@ import (
 type TextWidget struct {
 	BgColor, FgColor gocui.Attribute
 
-	view *gocui.View
-	text string
+	view   *gocui.View
+	text   string
+	styled StyledLine
 }
 
 // SetText updates the string to display.
 func (w *TextWidget) SetText(text string) {
 	w.text = text
+	w.styled = nil
 	w.render()
 }
 
@@ -78,7 +79,11 @@ func (w *TextWidget) setEditor(e gocui.Editor) {
 func (w *TextWidget) render() {
 	if w.view != nil {
 		w.view.Clear()
-		fmt.Fprintf(w.view, w.text)
+		if w.styled != nil {
+			writeStyled(w.view, w.styled)
+		} else {
+			fmt.Fprintf(w.view, w.text)
+		}
 	}
 }
 
@@ -191,6 +196,14 @@ type ListWidget struct {
 
 	base  ScrollWidget
 	model []string
+
+	matcher         Matcher
+	filterQuery     string
+	filterIdx       []int
+	filterPositions map[int][]int
+
+	filterGui     *gocui.Gui
+	filterOverlay *TextWidget
 }
 
 // View returns the gocui.View currently bound to this widget.
@@ -207,39 +220,93 @@ func (w *ListWidget) SetView(view *gocui.View) {
 
 // SetModel updates the list of lines to display.
 func (w *ListWidget) SetModel(model []string) {
-	w.base.SetMax(len(model))
 	w.model = model
-	w.render()
+	w.applyFilter()
 }
 
-// Current returns currently selected line.
+// Current returns the currently selected line's index into the model,
+// translated through the active filter if one is set.
 func (w *ListWidget) Current() int {
-	return w.base.Current()
+	idx := w.base.Current()
+	if w.filterIdx != nil {
+		if idx < 0 || idx >= len(w.filterIdx) {
+			return -1
+		}
+		return w.filterIdx[idx]
+	}
+	return idx
 }
 
-// SetCurrent updates currently selected line.
+// ModelIndex translates a visible row index (as reported by GetLine) into
+// its model index, through the active filter if one is set. It returns -1
+// if idx is out of range of the currently visible rows.
+func (w *ListWidget) ModelIndex(idx int) int {
+	if w.filterIdx != nil {
+		if idx < 0 || idx >= len(w.filterIdx) {
+			return -1
+		}
+		return w.filterIdx[idx]
+	}
+	return idx
+}
+
+// SetCurrent selects the line at the given model index, translated through
+// the active filter if one is set.
 func (w *ListWidget) SetCurrent(idx int) error {
+	if w.filterIdx != nil {
+		for visible, model := range w.filterIdx {
+			if model == idx {
+				return w.base.SetCurrent(visible)
+			}
+		}
+		return Error("not visible")
+	}
 	return w.base.SetCurrent(idx)
 }
 
+// HandleAction executes an action command.
+func (w *ListWidget) HandleAction(action string) error {
+	switch action {
+	case ActionStartFilter:
+		return w.startFilter()
+	case ActionClearFilter:
+		w.ClearFilter()
+		return nil
+	default:
+		return w.base.HandleAction(action)
+	}
+}
+
 func (w *ListWidget) render() {
 	view := w.base.View()
 
 	if view != nil {
 		view.Clear()
 		sx, _ := view.Size()
-		for i, line := range w.model {
+		rows := w.visibleRows()
+		for i, modelIdx := range rows {
 			if i > 0 {
 				fmt.Fprintf(view, "\n")
 			}
-			fmt.Fprintf(view, Pad(line, sx))
+			if w.filterQuery == "" {
+				fmt.Fprintf(view, Pad(w.model[modelIdx], sx))
+			} else {
+				line := highlightLine(w.model[modelIdx], w.filterPositions[modelIdx])
+				writeStyled(view, line.Pad(sx))
+			}
 		}
 	}
 }
 
-// HandleAction executes an action command.
-func (w *ListWidget) HandleAction(action string) error {
-	return w.base.HandleAction(action)
+func (w *ListWidget) visibleRows() []int {
+	if w.filterIdx != nil {
+		return w.filterIdx
+	}
+	rows := make([]int, len(w.model))
+	for i := range rows {
+		rows[i] = i
+	}
+	return rows
 }
 
 // GetLine returns currently selected line for a view (relative to origin).
@@ -313,69 +380,3 @@ func MoveLines(view *gocui.View, current, max, delta int) error {
 
 	return nil
 }
-
-// PromptEditor builds a gocui.Editor function letting user enter a line of text.
-func PromptEditor(g *gocui.Gui, offset int, callback func(bool, string)) gocui.Editor {
-	promptEditor := func(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) bool {
-		cx, _ := v.Cursor()
-		cancel := false
-		done := false
-		consumed := true
-
-		switch {
-		case ch != 0 && mod == 0:
-			v.EditWrite(ch)
-		case key == gocui.KeySpace:
-			v.EditWrite(' ')
-		case key == gocui.KeyBackspace || key == gocui.KeyBackspace2:
-			if cx > offset {
-				v.EditDelete(true)
-			} else {
-				cancel = true
-			}
-		case key == gocui.KeyDelete:
-			v.EditDelete(false)
-		case key == gocui.KeyInsert:
-			// v.Overwrite = !v.Overwrite
-		case key == gocui.KeyEnter:
-			// v.EditNewLine()
-			done = true
-		case key == gocui.KeyEsc || key == gocui.KeyCtrlG:
-			cancel = true
-		case key == gocui.KeyArrowDown:
-			// v.MoveCursor(0, 1, false)
-		case key == gocui.KeyArrowUp:
-			// v.MoveCursor(0, -1, false)
-		case key == gocui.KeyArrowLeft:
-			if cx > offset {
-				v.MoveCursor(-1, 0, false)
-			}
-		case key == gocui.KeyArrowRight:
-			v.MoveCursor(1, 0, false)
-		default:
-			consumed = false
-		}
-
-		if done || cancel {
-			content := strings.TrimSpace(getFirstLine(v.Buffer()))
-			if offset > len(content) {
-				offset = len(content)
-			}
-			callback(done, content[offset:])
-		}
-
-		return consumed
-	}
-
-	return gocui.EditorFunc(promptEditor)
-}
-
-func getFirstLine(s string) string {
-	idx := strings.Index(s, "\n")
-
-	if idx >= 0 {
-		return s[:idx]
-	}
-
-	return s
-}
@@ -68,6 +68,12 @@ type Xui struct {
 
 	preActionFunc  func()
 	postActionFunc func(error) error
+
+	widgets map[string]Widget
+	layout  *Layout
+
+	bindings        []keyBinding
+	focusChangeFunc func(string)
 }
 
 // New wraps a gocui.Gui instance.
@@ -109,21 +115,52 @@ func (gx *Xui) callPostActionHandler(err error) error {
 
 // SetKeybinding is a wrapper around gocui.Gui.SetKeybinding.
 func (gx *Xui) SetKeybinding(viewname string, key interface{}, mod gocui.Modifier, handler func(*gocui.Gui, *gocui.View) error) {
+	gx.setKeybinding(viewname, key, mod, "", handler)
+}
+
+// SetKeybindingWithLabel is like SetKeybinding but also records the binding
+// under label so it can be discovered by a StatusBar.
+func (gx *Xui) SetKeybindingWithLabel(viewname string, key interface{}, mod gocui.Modifier, label string, handler func(*gocui.Gui, *gocui.View) error) {
+	gx.setKeybinding(viewname, key, mod, label, handler)
+}
+
+func (gx *Xui) setKeybinding(viewname string, key interface{}, mod gocui.Modifier, label string, handler func(*gocui.Gui, *gocui.View) error) {
 	if gx.err == nil {
 		gx.err = gx.g.SetKeybinding(viewname, key, mod, func(g *gocui.Gui, view *gocui.View) error {
 			gx.callPreActionHandler()
 			return gx.callPostActionHandler(handler(g, view))
 		})
+		if gx.err == nil && label != "" {
+			gx.recordBinding(viewname, key, mod, label)
+		}
 	}
 }
 
+func (gx *Xui) registerWidget(viewname string, widget Widget) {
+	if gx.widgets == nil {
+		gx.widgets = make(map[string]Widget)
+	}
+	gx.widgets[viewname] = widget
+}
+
 // SetWidgetKeybinding is a wrapper around gocui.Gui.SetKeybinding.
 func (gx *Xui) SetWidgetKeybinding(widget Widget, key interface{}, mod gocui.Modifier, handler func() error) {
+	gx.setWidgetKeybinding(widget, key, mod, "", handler)
+}
+
+// SetWidgetKeybindingWithLabel is like SetWidgetKeybinding but also records
+// the binding under label so it can be discovered by a StatusBar.
+func (gx *Xui) SetWidgetKeybindingWithLabel(widget Widget, key interface{}, mod gocui.Modifier, label string, handler func() error) {
+	gx.setWidgetKeybinding(widget, key, mod, label, handler)
+}
+
+func (gx *Xui) setWidgetKeybinding(widget Widget, key interface{}, mod gocui.Modifier, label string, handler func() error) {
 	if gx.err == nil {
 		view := widget.View()
 		if view == nil {
 			gx.err = errors.New("widget has no view")
 		} else {
+			gx.registerWidget(view.Name(), widget)
 			gx.err = gx.g.SetKeybinding(view.Name(), key, mod,
 				func(*gocui.Gui, *gocui.View) error {
 					gx.callPreActionHandler()
@@ -133,17 +170,31 @@ func (gx *Xui) SetWidgetKeybinding(widget Widget, key interface{}, mod gocui.Mod
 					}
 					return gx.callPostActionHandler(err)
 				})
+			if gx.err == nil && label != "" {
+				gx.recordBinding(view.Name(), key, mod, label)
+			}
 		}
 	}
 }
 
 // SetWidgetAction is a wrapper around gocui.Gui.SetKeybinding for sending an action command to widget.
 func (gx *Xui) SetWidgetAction(widget Widget, key interface{}, mod gocui.Modifier, action string) {
+	gx.setWidgetAction(widget, key, mod, action, "")
+}
+
+// SetWidgetActionWithLabel is like SetWidgetAction but also records the
+// binding under label so it can be discovered by a StatusBar.
+func (gx *Xui) SetWidgetActionWithLabel(widget Widget, key interface{}, mod gocui.Modifier, action, label string) {
+	gx.setWidgetAction(widget, key, mod, action, label)
+}
+
+func (gx *Xui) setWidgetAction(widget Widget, key interface{}, mod gocui.Modifier, action, label string) {
 	if gx.err == nil {
 		view := widget.View()
 		if view == nil {
 			gx.err = errors.New("widget has no view")
 		} else {
+			gx.registerWidget(view.Name(), widget)
 			gx.err = gx.g.SetKeybinding(view.Name(), key, mod,
 				func(*gocui.Gui, *gocui.View) error {
 					gx.callPreActionHandler()
@@ -156,6 +207,9 @@ func (gx *Xui) SetWidgetAction(widget Widget, key interface{}, mod gocui.Modifie
 					gx.callPostActionHandler(err)
 					return nil
 				})
+			if gx.err == nil && label != "" {
+				gx.recordBinding(view.Name(), key, mod, label)
+			}
 		}
 	}
 }
@@ -210,14 +264,28 @@ func (gx *Xui) Focus(view *gocui.View) {
 	}
 }
 
-// FocusName changes focus to view with provided name.
+// FocusName changes focus to view with provided name. If a Layout has been
+// applied via ApplyLayout, name must be a view present in that tree.
 func (gx *Xui) FocusName(name string) {
 	if name != "" {
+		if gx.layout != nil && !gx.layout.hasView(name) {
+			gx.err = fmt.Errorf("xui: no such view in layout: %s", name)
+			return
+		}
 		gx.SetViewOnTop(name)
 		gx.SetCurrentView(name)
+		if gx.focusChangeFunc != nil {
+			gx.focusChangeFunc(name)
+		}
 	}
 }
 
+// SetFocusChangeHook sets a hook that is called with the view name whenever
+// FocusName changes focus.
+func (gx *Xui) SetFocusChangeHook(f func(viewname string)) {
+	gx.focusChangeFunc = f
+}
+
 // A Region represents the area occupied by a gocui.View without the outer frame.
 type Region struct {
 	Left   int